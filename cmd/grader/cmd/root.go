@@ -7,7 +7,9 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"grader/internal/app/grader/config"
+	"go.uber.org/fx"
+	panelapp "grader/internal/app/panel/app"
+	"grader/internal/app/panel/config"
 	"grader/pkg/logger"
 	"io/fs"
 	"strings"
@@ -20,7 +22,11 @@ var rootCmd = &cobra.Command{
 	Short: "Start grader service",
 	Long:  `Grader grader service`,
 	Run: func(cmd *cobra.Command, args []string) {
-		logger.CheckErr(cmd.Help())
+		fx.New(
+			fx.Supply(cfg),
+			panelapp.Module,
+			fx.NopLogger,
+		).Run()
 	},
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		return nil
@@ -55,6 +61,24 @@ listen="localhost:8090"
 timeout_read="5s"
 timeout_write="5s"
 timeout_idle="1m"
+[db]
+dsn=""
+[amqp]
+dsn=""
+[redis]
+host="localhost:6379"
+password=""
+db=0
+[aws]
+region=""
+bucket=""
+access_key_id=""
+secret_access_key=""
+[security]
+secret_key=""
+[app]
+topic_name="grader-submissions"
+result_topic_name="grader-verdicts"
 [log]
 verbose=0
 pretty=0
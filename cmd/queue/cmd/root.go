@@ -7,6 +7,8 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"go.uber.org/fx"
+	queueapp "grader/internal/app/queue/app"
 	"grader/internal/app/queue/config"
 	"grader/pkg/logger"
 	"io/fs"
@@ -20,7 +22,11 @@ var rootCmd = &cobra.Command{
 	Short: "Start queue service",
 	Long:  `Grader queue service`,
 	Run: func(cmd *cobra.Command, args []string) {
-		logger.CheckErr(cmd.Help())
+		fx.New(
+			fx.Supply(cfg),
+			queueapp.Module,
+			fx.NopLogger,
+		).Run()
 	},
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		return nil
@@ -0,0 +1,267 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-redis/redis/v8"
+	_ "github.com/lib/pq"
+	"go.uber.org/fx"
+	"grader/internal/app/panel/config"
+	"grader/internal/app/panel/handler"
+	"grader/internal/app/panel/pkg/auth"
+	"grader/internal/app/panel/storage/postgres"
+	"grader/internal/pkg/migrate"
+	"grader/pkg/aws"
+	"grader/pkg/httpserver"
+	"grader/pkg/layout"
+	"grader/pkg/logger"
+	"grader/pkg/logstream"
+	mw "grader/pkg/middleware"
+	"grader/pkg/queue"
+	"grader/pkg/queue/amqp"
+	"grader/pkg/session"
+	"grader/pkg/token"
+	"grader/pkg/workerpool"
+	"grader/web"
+	"net/http"
+	"runtime"
+	"time"
+)
+
+// Module wires every panel dependency as an fx provider and registers
+// lifecycle hooks so `cmd/grader` can be reduced to an fx.New(Module).Run().
+var Module = fx.Module("panel",
+	fx.Provide(
+		provideLogger,
+		providePostgres,
+		provideAMQP,
+		provideRedis,
+		provideS3,
+		provideTokenManager,
+		provideSession,
+		provideWorkerPool,
+		postgres.NewUserRepository,
+		postgres.NewAssessmentRepository,
+		postgres.NewSubmissionRepository,
+		postgres.NewProblemRepository,
+		provideLogBroker,
+		provideLayout,
+		provideRouter,
+		provideHTTPServer,
+	),
+	fx.Invoke(registerWorkerPool, registerResultConsumer),
+)
+
+func provideLogger() logger.Logger {
+	return *logger.Global()
+}
+
+func providePostgres(cfg config.Config, lc fx.Lifecycle) (*sql.DB, error) {
+	db, err := sql.Open("postgres", cfg.DB.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("db open: %w", err)
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if err := db.PingContext(ctx); err != nil {
+				return fmt.Errorf("db ping: %w", err)
+			}
+			return migrate.Up(db)
+		},
+		OnStop: func(ctx context.Context) error {
+			return db.Close()
+		},
+	})
+
+	return db, nil
+}
+
+func provideAMQP(cfg config.Config, lc fx.Lifecycle) (queue.Queue, error) {
+	q, err := amqp.New(cfg.AMQP)
+	if err != nil {
+		return nil, fmt.Errorf("amqp: %w", err)
+	}
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			q.Stop()
+			return nil
+		},
+	})
+
+	return q, nil
+}
+
+func provideRedis(cfg config.Config, lc fx.Lifecycle) *redis.Client {
+	rds := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.Host,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return rds.Close()
+		},
+	})
+
+	return rds
+}
+
+func provideS3(cfg config.Config) (*aws.S3, error) {
+	return aws.NewS3(cfg.AWS)
+}
+
+func provideTokenManager(cfg config.Config) (token.Manager, error) {
+	return token.NewJWT(cfg.Security.SecretKey)
+}
+
+func provideSession(rds *redis.Client, tm token.Manager) *session.Manager {
+	return session.NewRedis(
+		rds,
+		tm,
+		session.WithSessionLifetime(15*time.Minute),
+		session.WithRefreshLifetime(30*24*time.Hour),
+	)
+}
+
+func provideWorkerPool() *workerpool.Pool {
+	return workerpool.New()
+}
+
+func registerWorkerPool(wp *workerpool.Pool, lc fx.Lifecycle) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			wp.Start(runtime.GOMAXPROCS(0) * 2)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			wp.Stop()
+			return nil
+		},
+	})
+}
+
+func provideLogBroker(rds *redis.Client) *logstream.Broker {
+	return logstream.NewBroker(rds, 4096)
+}
+
+func provideLayout(tm token.Manager) (*layout.Layout, error) {
+	return layout.NewLayout(
+		web.TemplatesFS,
+		"template/app/layouts/base.gohtml",
+		handler.ViewDataFunc(tm),
+	)
+}
+
+func provideRouter(
+	cfg config.Config,
+	l logger.Logger,
+	lt *layout.Layout,
+	sm *session.Manager,
+	users *postgres.UserRepository,
+	assessments *postgres.AssessmentRepository,
+	submissions *postgres.SubmissionRepository,
+	problems *postgres.ProblemRepository,
+	s3 *aws.S3,
+	q queue.Queue,
+	logs *logstream.Broker,
+) (*chi.Mux, error) {
+	r := chi.NewRouter()
+	r.Use(middleware.Recoverer)
+	r.Use(mw.Log(l))
+
+	uh := handler.NewUserHandler(lt, sm, users)
+	ah := handler.NewAdminHandler(lt, users, assessments)
+	sh, err := handler.NewSubmitHandler(lt, s3, q, cfg.App.TopicName, users, assessments, submissions, logs)
+	if err != nil {
+		return nil, fmt.Errorf("submission handler: %w", err)
+	}
+	ph, err := handler.NewProblemHandler(lt, s3, problems, assessments)
+	if err != nil {
+		return nil, fmt.Errorf("problem handler: %w", err)
+	}
+
+	r.Route("/app", func(r chi.Router) {
+		r.Use(session.ContextMiddleware(sm))
+		r.Use(auth.ContextMiddleware(users, sm))
+
+		r.Route("/submit", func(r chi.Router) {
+			r.Use(auth.AuthMiddleware())
+
+			r.Get("/{id}", sh.Create)
+			r.Post("/{id}", sh.Create)
+			r.Get("/{id}/stream", sh.Stream)
+		})
+
+		r.Route("/user", func(r chi.Router) {
+			r.Get("/login", uh.Login)
+			r.Post("/login", uh.Login)
+
+			r.Get("/register", uh.Register)
+			r.Post("/register", uh.Register)
+
+			r.Get("/logout", uh.Logout)
+			r.Post("/refresh", uh.Refresh)
+
+			r.Group(func(r chi.Router) {
+				r.Use(auth.AuthMiddleware())
+				r.Post("/logout-all", uh.LogoutAll)
+			})
+		})
+
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(auth.AuthMiddleware())
+			r.Use(auth.ACAdmin())
+
+			r.Get("/assessments", ah.AssessmentList)
+
+			r.Get("/assessments/create", ah.AssessmentCreate)
+			r.Post("/assessments/create", ah.AssessmentCreate)
+
+			r.Get("/assessments/{id}/problems", ph.List)
+			r.Get("/assessments/{id}/problems/upload", ph.Upload)
+			r.Post("/assessments/{id}/problems/upload", ph.Upload)
+			r.Get("/assessments/{id}/problems/{problemID}/edit", ph.Edit)
+			r.Post("/assessments/{id}/problems/{problemID}/edit", ph.Edit)
+		})
+
+		r.Route("/api", func(r chi.Router) {
+			r.Use(auth.AuthMiddleware())
+			r.Use(auth.ACTeacher())
+
+			r.Get("/problem/{id}", ph.Snapshot)
+		})
+
+		r.Get("/", uh.Default)
+	})
+
+	static := http.FileServer(http.FS(web.StaticFS))
+	r.Handle("/static/*", static)
+
+	return r, nil
+}
+
+func provideHTTPServer(cfg config.Config, r *chi.Mux, lc fx.Lifecycle) (*httpserver.Server, error) {
+	hs, err := httpserver.New(cfg.Server, r)
+	if err != nil {
+		return nil, fmt.Errorf("http server: %w", err)
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			go hs.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			hs.Stop()
+			return nil
+		},
+	})
+
+	return hs, nil
+}
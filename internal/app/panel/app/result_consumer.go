@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go.uber.org/fx"
+	"grader/internal/app/panel/config"
+	"grader/internal/app/panel/storage/postgres"
+	"grader/internal/pkg/model"
+	"grader/pkg/logger"
+	"grader/pkg/queue"
+	"grader/pkg/workerpool"
+)
+
+// resultConsumer subscribes to the runner's result topic and persists each
+// judged submission's per-testcase results, so they have a durable home
+// beyond the one-shot queue message the runner publishes.
+type resultConsumer struct {
+	logger      logger.Logger
+	submissions *postgres.SubmissionRepository
+}
+
+func (c *resultConsumer) consume(messages <-chan []byte) {
+	for raw := range messages {
+		var result model.SubmissionResult
+		if err := json.Unmarshal(raw, &result); err != nil {
+			c.logger.Error().Err(err).Msg("decode submission result")
+			continue
+		}
+
+		if err := c.submissions.SaveResult(context.Background(), result); err != nil {
+			c.logger.Error().Err(err).Str("submission_id", result.SubmissionID).Msg("save submission result")
+		}
+	}
+}
+
+func registerResultConsumer(cfg config.Config, q queue.Queue, submissions *postgres.SubmissionRepository, l logger.Logger, wp *workerpool.Pool, lc fx.Lifecycle) {
+	c := &resultConsumer{logger: l, submissions: submissions}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			messages, err := q.Subscribe(cfg.App.ResultTopicName)
+			if err != nil {
+				return fmt.Errorf("subscribe: %w", err)
+			}
+
+			wp.Submit(func() {
+				c.consume(messages)
+			})
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"grader/pkg/logger"
+)
+
+type Config struct {
+	Server   Server
+	DB       DB
+	AMQP     AMQP
+	Redis    Redis
+	AWS      AWS
+	Security Security
+	App      App
+	Logger   logger.Config `mapstructure:"log"`
+}
+
+type Server struct {
+	Listen       string `mapstructure:"listen"`
+	TimeoutRead  string `mapstructure:"timeout_read"`
+	TimeoutWrite string `mapstructure:"timeout_write"`
+	TimeoutIdle  string `mapstructure:"timeout_idle"`
+}
+
+type DB struct {
+	DSN string `mapstructure:"dsn"`
+}
+
+type AMQP struct {
+	DSN string `mapstructure:"dsn"`
+}
+
+type Redis struct {
+	Host     string `mapstructure:"host"`
+	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
+}
+
+type AWS struct {
+	Region          string `mapstructure:"region"`
+	Bucket          string `mapstructure:"bucket"`
+	AccessKeyID     string `mapstructure:"access_key_id"`
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+}
+
+type Security struct {
+	SecretKey string `mapstructure:"secret_key"`
+}
+
+type App struct {
+	TopicName       string `mapstructure:"topic_name"`
+	ResultTopicName string `mapstructure:"result_topic_name"`
+}
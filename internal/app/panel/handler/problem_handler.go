@@ -0,0 +1,288 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+	"grader/internal/app/panel/storage/postgres"
+	"grader/internal/pkg/model"
+	"grader/pkg/aws"
+	"grader/pkg/layout"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type ProblemHandler struct {
+	layout      *layout.Layout
+	s3          *aws.S3
+	problems    *postgres.ProblemRepository
+	assessments *postgres.AssessmentRepository
+}
+
+func NewProblemHandler(lt *layout.Layout, s3 *aws.S3, problems *postgres.ProblemRepository, assessments *postgres.AssessmentRepository) (*ProblemHandler, error) {
+	return &ProblemHandler{layout: lt, s3: s3, problems: problems, assessments: assessments}, nil
+}
+
+func (h *ProblemHandler) List(w http.ResponseWriter, r *http.Request) {
+	assessmentID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid assessment id", http.StatusBadRequest)
+		return
+	}
+
+	problems, err := h.problems.ListByAssessment(r.Context(), assessmentID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.layout.Render(w, r, "template/app/admin/problem_list.gohtml", map[string]any{
+		"AssessmentID": assessmentID,
+		"Problems":     problems,
+	})
+}
+
+// Edit renders the current problem for editing and, on POST, applies the
+// statement/limits/languages changes, bumping Version and freezing a new
+// snapshot while leaving the existing testcases untouched.
+func (h *ProblemHandler) Edit(w http.ResponseWriter, r *http.Request) {
+	problemID, err := uuid.Parse(chi.URLParam(r, "problemID"))
+	if err != nil {
+		http.Error(w, "invalid problem id", http.StatusBadRequest)
+		return
+	}
+
+	problem, err := h.problems.Get(r.Context(), problemID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		h.layout.Render(w, r, "template/app/admin/problem_edit.gohtml", map[string]any{
+			"Problem": problem,
+		})
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	problem.Statement = r.FormValue("statement")
+	if problem.TimeLimitMs, err = strconv.Atoi(r.FormValue("time_limit_ms")); err != nil {
+		http.Error(w, "invalid time_limit_ms", http.StatusBadRequest)
+		return
+	}
+	if problem.MemoryLimitKB, err = strconv.Atoi(r.FormValue("memory_limit_kb")); err != nil {
+		http.Error(w, "invalid memory_limit_kb", http.StatusBadRequest)
+		return
+	}
+	problem.Languages = strings.Split(r.FormValue("languages"), ",")
+
+	testcases, err := h.problems.Testcases(r.Context(), problemID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.problems.Update(r.Context(), problem, testcases); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/app/admin/assessments/%s/problems", problem.AssessmentID), http.StatusFound)
+}
+
+// Upload accepts a multipart-form .zip containing problem.yaml plus
+// tests/*.in|*.ans fixtures, stores each testcase blob in S3, and creates the
+// problem at version 1.
+func (h *ProblemHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	assessmentID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid assessment id", http.StatusBadRequest)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		h.layout.Render(w, r, "template/app/admin/problem_upload.gohtml", map[string]any{
+			"AssessmentID": assessmentID,
+		})
+		return
+	}
+
+	file, _, err := r.FormFile("archive")
+	if err != nil {
+		http.Error(w, "archive: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	raw, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(raw), int64(len(raw)))
+	if err != nil {
+		http.Error(w, "not a zip archive: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	problem, testcases, err := h.importArchive(r.Context(), assessmentID, zr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.problems.Create(r.Context(), problem, testcases); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/app/admin/assessments/%s/problems", assessmentID), http.StatusFound)
+}
+
+type problemManifest struct {
+	Statement     string   `yaml:"statement"`
+	TimeLimitMs   int      `yaml:"time_limit_ms"`
+	MemoryLimitKB int      `yaml:"memory_limit_kb"`
+	Languages     []string `yaml:"languages"`
+}
+
+func (h *ProblemHandler) importArchive(ctx context.Context, assessmentID uuid.UUID, zr *zip.Reader) (*model.Problem, []model.Testcase, error) {
+	var manifest problemManifest
+	inputs := map[string]*zip.File{}
+	expected := map[string]*zip.File{}
+
+	for _, f := range zr.File {
+		switch {
+		case f.Name == "problem.yaml":
+			rc, err := f.Open()
+			if err != nil {
+				return nil, nil, fmt.Errorf("open problem.yaml: %w", err)
+			}
+			defer rc.Close()
+			if err := yaml.NewDecoder(rc).Decode(&manifest); err != nil {
+				return nil, nil, fmt.Errorf("decode problem.yaml: %w", err)
+			}
+		case strings.HasPrefix(f.Name, "tests/") && strings.HasSuffix(f.Name, ".in"):
+			inputs[strings.TrimSuffix(path.Base(f.Name), ".in")] = f
+		case strings.HasPrefix(f.Name, "tests/") && strings.HasSuffix(f.Name, ".ans"):
+			expected[strings.TrimSuffix(path.Base(f.Name), ".ans")] = f
+		}
+	}
+
+	var names []string
+	for name := range inputs {
+		if _, ok := expected[name]; !ok {
+			return nil, nil, fmt.Errorf("testcase %q is missing its .ans file", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	problem := &model.Problem{
+		AssessmentID:  assessmentID,
+		Statement:     manifest.Statement,
+		TimeLimitMs:   manifest.TimeLimitMs,
+		MemoryLimitKB: manifest.MemoryLimitKB,
+		Languages:     manifest.Languages,
+	}
+
+	// Each upload gets its own key prefix so a later upload to the same
+	// assessment cannot overwrite blobs an earlier snapshot points at.
+	uploadID := uuid.NewString()
+
+	var testcases []model.Testcase
+	for i, name := range names {
+		ordinal := i + 1
+
+		input, err := readZipFile(inputs[name])
+		if err != nil {
+			return nil, nil, err
+		}
+		ans, err := readZipFile(expected[name])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		inputKey := fmt.Sprintf("problems/%s/%s/%d.in", assessmentID, uploadID, ordinal)
+		expectedKey := fmt.Sprintf("problems/%s/%s/%d.ans", assessmentID, uploadID, ordinal)
+
+		if err := h.s3.PutObject(ctx, inputKey, input); err != nil {
+			return nil, nil, fmt.Errorf("upload %s: %w", inputKey, err)
+		}
+		if err := h.s3.PutObject(ctx, expectedKey, ans); err != nil {
+			return nil, nil, fmt.Errorf("upload %s: %w", expectedKey, err)
+		}
+
+		testcases = append(testcases, model.Testcase{
+			Ordinal:     ordinal,
+			InputKey:    inputKey,
+			ExpectedKey: expectedKey,
+			Hidden:      true,
+			Points:      1,
+		})
+	}
+
+	return problem, testcases, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// Snapshot returns the immutable problem+testcases view at ?version=N (the
+// current version if omitted), the payload the runner fetches before
+// judging a submission.
+func (h *ProblemHandler) Snapshot(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid problem id", http.StatusBadRequest)
+		return
+	}
+
+	version := 0
+	if raw := r.URL.Query().Get("version"); raw != "" {
+		version, err = strconv.Atoi(raw)
+		if err != nil {
+			http.Error(w, "invalid version", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if version == 0 {
+		problem, err := h.problems.Get(r.Context(), id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		version = problem.Version
+	}
+
+	snapshot, err := h.problems.Snapshot(r.Context(), id, version)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(snapshot)
+}
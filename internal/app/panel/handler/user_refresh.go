@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"encoding/json"
+	"grader/internal/app/panel/pkg/auth"
+	"net/http"
+)
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type refreshResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Refresh rotates a refresh token for a new access/refresh pair, rejecting
+// it if it has already been used or revoked.
+func (h *UserHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req refreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	access, refresh, err := h.sessions.Refresh(r.Context(), r.UserAgent(), req.RefreshToken)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(refreshResponse{AccessToken: access, RefreshToken: refresh})
+}
+
+// LogoutAll revokes every session belonging to the signed-in user, signing
+// every device using this account out at once.
+func (h *UserHandler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "not signed in", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.sessions.LogoutAll(r.Context(), user.Identity()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"context"
+	"grader/internal/pkg/model"
+	"grader/pkg/session"
+	"net/http"
+)
+
+type ctxKey string
+
+const userCtxKey ctxKey = "auth.user"
+
+type UserRepository interface {
+	FindByID(ctx context.Context, id string) (*model.User, error)
+}
+
+// RevocationChecker reports whether the session identified by a claim's
+// (userID, sid) pair has been revoked, e.g. via session.Manager.LogoutAll.
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, userID, sid string) (bool, error)
+}
+
+// ContextMiddleware resolves the signed-in session claims into a *model.User
+// and stores it on the request context for downstream handlers and
+// middleware (AuthMiddleware, ACMust) to consume. An access token whose sid
+// references a revoked session is treated as anonymous rather than erroring.
+func ContextMiddleware(users UserRepository, revocation RevocationChecker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := session.ClaimsFromContext(r.Context())
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if revoked, err := revocation.IsRevoked(r.Context(), claims.UserID, claims.SID); err != nil || revoked {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			user, err := users.FindByID(r.Context(), claims.UserID)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), userCtxKey, user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// UserFromContext returns the user resolved by ContextMiddleware, if any.
+func UserFromContext(ctx context.Context) (*model.User, bool) {
+	u, ok := ctx.Value(userCtxKey).(*model.User)
+	return u, ok
+}
+
+// AuthMiddleware requires a signed-in user, redirecting to the login page
+// otherwise.
+func AuthMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := UserFromContext(r.Context()); !ok {
+				http.Redirect(w, r, "/app/user/login", http.StatusFound)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"net/http"
+)
+
+const (
+	GroupSystem  = "system"
+	GroupAdmin   = "admin"
+	GroupTeacher = "teacher"
+	GroupStudent = "student"
+)
+
+// ACMust requires the signed-in user to belong to at least one of the given
+// groups, aborting with 403 Forbidden otherwise. It must run after
+// AuthMiddleware, which guarantees a user is present on the context.
+func ACMust(groups ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := UserFromContext(r.Context())
+			if !ok || !user.HasGroup(groups...) {
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ACAdmin shortcuts ACMust for the system/admin groups.
+func ACAdmin() func(http.Handler) http.Handler {
+	return ACMust(GroupSystem, GroupAdmin)
+}
+
+// ACTeacher shortcuts ACMust for the system/admin/teacher groups.
+func ACTeacher() func(http.Handler) http.Handler {
+	return ACMust(GroupSystem, GroupAdmin, GroupTeacher)
+}
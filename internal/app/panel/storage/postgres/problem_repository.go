@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"grader/internal/pkg/model"
+)
+
+type ProblemRepository struct {
+	db *sql.DB
+}
+
+func NewProblemRepository(db *sql.DB) (*ProblemRepository, error) {
+	return &ProblemRepository{db: db}, nil
+}
+
+func (r *ProblemRepository) Get(ctx context.Context, id uuid.UUID) (*model.Problem, error) {
+	p := &model.Problem{}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, assessment_id, statement, time_limit_ms, memory_limit_kb, languages, version
+		FROM problems WHERE id = $1
+	`, id).Scan(&p.ID, &p.AssessmentID, &p.Statement, &p.TimeLimitMs, &p.MemoryLimitKB, pq.Array(&p.Languages), &p.Version)
+	if err != nil {
+		return nil, fmt.Errorf("get problem: %w", err)
+	}
+	return p, nil
+}
+
+func (r *ProblemRepository) ListByAssessment(ctx context.Context, assessmentID uuid.UUID) ([]model.Problem, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, assessment_id, statement, time_limit_ms, memory_limit_kb, languages, version
+		FROM problems WHERE assessment_id = $1 ORDER BY id
+	`, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("list problems: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.Problem
+	for rows.Next() {
+		var p model.Problem
+		if err := rows.Scan(&p.ID, &p.AssessmentID, &p.Statement, &p.TimeLimitMs, &p.MemoryLimitKB, pq.Array(&p.Languages), &p.Version); err != nil {
+			return nil, fmt.Errorf("scan problem: %w", err)
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+func (r *ProblemRepository) Testcases(ctx context.Context, problemID uuid.UUID) ([]model.Testcase, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, problem_id, ordinal, input_key, expected_key, hidden, points
+		FROM testcases WHERE problem_id = $1 ORDER BY ordinal
+	`, problemID)
+	if err != nil {
+		return nil, fmt.Errorf("list testcases: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.Testcase
+	for rows.Next() {
+		var tc model.Testcase
+		if err := rows.Scan(&tc.ID, &tc.ProblemID, &tc.Ordinal, &tc.InputKey, &tc.ExpectedKey, &tc.Hidden, &tc.Points); err != nil {
+			return nil, fmt.Errorf("scan testcase: %w", err)
+		}
+		out = append(out, tc)
+	}
+	return out, rows.Err()
+}
+
+// Create persists a new problem at version 1 together with its testcases and
+// freezes the matching snapshot.
+func (r *ProblemRepository) Create(ctx context.Context, p *model.Problem, testcases []model.Testcase) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	p.Version = 1
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO problems (assessment_id, statement, time_limit_ms, memory_limit_kb, languages, version)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`, p.AssessmentID, p.Statement, p.TimeLimitMs, p.MemoryLimitKB, pq.Array(p.Languages), p.Version).Scan(&p.ID)
+	if err != nil {
+		return fmt.Errorf("create problem: %w", err)
+	}
+
+	for i := range testcases {
+		testcases[i].ProblemID = p.ID
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO testcases (problem_id, ordinal, input_key, expected_key, hidden, points)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, p.ID, testcases[i].Ordinal, testcases[i].InputKey, testcases[i].ExpectedKey, testcases[i].Hidden, testcases[i].Points); err != nil {
+			return fmt.Errorf("insert testcase: %w", err)
+		}
+	}
+
+	snapshot, err := json.Marshal(model.ProblemSnapshot{Problem: *p, Testcases: toRunnerTestcases(testcases)})
+	if err != nil {
+		return fmt.Errorf("marshal snapshot: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO problem_versions (problem_id, version, snapshot) VALUES ($1, $2, $3)
+	`, p.ID, p.Version, snapshot); err != nil {
+		return fmt.Errorf("freeze snapshot: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// Update persists an edited problem and its testcases, bumping Version and
+// freezing a snapshot so submissions already judged against the prior
+// version remain reproducible.
+func (r *ProblemRepository) Update(ctx context.Context, p *model.Problem, testcases []model.Testcase) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	p.Version++
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE problems SET statement = $2, time_limit_ms = $3, memory_limit_kb = $4, languages = $5, version = $6
+		WHERE id = $1
+	`, p.ID, p.Statement, p.TimeLimitMs, p.MemoryLimitKB, pq.Array(p.Languages), p.Version); err != nil {
+		return fmt.Errorf("update problem: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM testcases WHERE problem_id = $1`, p.ID); err != nil {
+		return fmt.Errorf("clear testcases: %w", err)
+	}
+
+	for i := range testcases {
+		testcases[i].ProblemID = p.ID
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO testcases (problem_id, ordinal, input_key, expected_key, hidden, points)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, p.ID, testcases[i].Ordinal, testcases[i].InputKey, testcases[i].ExpectedKey, testcases[i].Hidden, testcases[i].Points); err != nil {
+			return fmt.Errorf("insert testcase: %w", err)
+		}
+	}
+
+	snapshot, err := json.Marshal(model.ProblemSnapshot{Problem: *p, Testcases: toRunnerTestcases(testcases)})
+	if err != nil {
+		return fmt.Errorf("marshal snapshot: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO problem_versions (problem_id, version, snapshot) VALUES ($1, $2, $3)
+	`, p.ID, p.Version, snapshot); err != nil {
+		return fmt.Errorf("freeze snapshot: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func toRunnerTestcases(testcases []model.Testcase) []model.RunnerTestcase {
+	out := make([]model.RunnerTestcase, len(testcases))
+	for i, tc := range testcases {
+		out[i] = model.RunnerTestcase{
+			ID:          tc.ID,
+			Ordinal:     tc.Ordinal,
+			InputKey:    tc.InputKey,
+			ExpectedKey: tc.ExpectedKey,
+			Hidden:      tc.Hidden,
+			Points:      tc.Points,
+		}
+	}
+	return out
+}
+
+// Snapshot returns the immutable problem+testcases view frozen at the given
+// version, the payload the runner fetches before judging.
+func (r *ProblemRepository) Snapshot(ctx context.Context, problemID uuid.UUID, version int) (*model.ProblemSnapshot, error) {
+	var raw []byte
+	err := r.db.QueryRowContext(ctx, `
+		SELECT snapshot FROM problem_versions WHERE problem_id = $1 AND version = $2
+	`, problemID, version).Scan(&raw)
+	if err != nil {
+		return nil, fmt.Errorf("get snapshot: %w", err)
+	}
+
+	var snapshot model.ProblemSnapshot
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil, fmt.Errorf("decode snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
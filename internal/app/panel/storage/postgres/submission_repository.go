@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"grader/internal/pkg/model"
+)
+
+type SubmissionRepository struct {
+	db *sql.DB
+}
+
+func NewSubmissionRepository(db *sql.DB) (*SubmissionRepository, error) {
+	return &SubmissionRepository{db: db}, nil
+}
+
+// SaveResult persists every TestcaseResult of a judged submission, giving
+// the result a durable home alongside the one-shot copy the runner publishes
+// to the result queue topic.
+func (r *SubmissionRepository) SaveResult(ctx context.Context, result model.SubmissionResult) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, tc := range result.Testcases {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO submission_testcase_results (submission_id, ordinal, verdict, time_ms, memory_kb)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (submission_id, ordinal) DO UPDATE
+				SET verdict = EXCLUDED.verdict, time_ms = EXCLUDED.time_ms, memory_kb = EXCLUDED.memory_kb
+		`, result.SubmissionID, tc.Ordinal, tc.Verdict, tc.TimeMs, tc.MemoryKB); err != nil {
+			return fmt.Errorf("insert testcase result %d: %w", tc.Ordinal, err)
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/fx"
+	"grader/internal/app/queue/config"
+	"grader/pkg/logger"
+	"grader/pkg/queue"
+	"grader/pkg/queue/amqp"
+)
+
+// Module wires the queue service's dependencies as fx providers so
+// `cmd/queue` can be reduced to an fx.New(Module).Run() call, mirroring the
+// panel and runner modules.
+var Module = fx.Module("queue",
+	fx.Provide(
+		provideLogger,
+		provideAMQP,
+	),
+	fx.Invoke(registerAMQP),
+)
+
+func provideLogger() logger.Logger {
+	return *logger.Global()
+}
+
+func provideAMQP(cfg config.Config) (queue.Queue, error) {
+	q, err := amqp.New(cfg.AMQP)
+	if err != nil {
+		return nil, fmt.Errorf("amqp: %w", err)
+	}
+	return q, nil
+}
+
+func registerAMQP(q queue.Queue, l logger.Logger, lc fx.Lifecycle) {
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			q.Stop()
+			return nil
+		},
+	})
+}
@@ -0,0 +1,19 @@
+package config
+
+import (
+	"grader/pkg/logger"
+)
+
+type Config struct {
+	App    App
+	AMQP   AMQP
+	Logger logger.Config `mapstructure:"log"`
+}
+
+type App struct {
+	TopicName string `mapstructure:"topic_name"`
+}
+
+type AMQP struct {
+	DSN string `mapstructure:"dsn"`
+}
@@ -0,0 +1,122 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/fx"
+	"grader/internal/app/runner/config"
+	"grader/internal/app/runner/judge"
+	"grader/pkg/aws"
+	"grader/pkg/logger"
+	"grader/pkg/logstream"
+	"grader/pkg/queue"
+	"grader/pkg/queue/amqp"
+	"grader/pkg/workerpool"
+)
+
+// Module wires every runner dependency as an fx provider and registers
+// lifecycle hooks so `cmd/runner` can be reduced to an fx.New(Module).Run().
+var Module = fx.Module("runner",
+	fx.Provide(
+		provideLogger,
+		provideAMQP,
+		provideS3,
+		provideRedis,
+		provideLogBroker,
+		provideSandbox,
+		provideJudge,
+		provideWorkerPool,
+		provideSnapshotFetcher,
+		provideService,
+	),
+	fx.Invoke(registerConsumer),
+)
+
+func provideLogger() logger.Logger {
+	return *logger.Global()
+}
+
+func provideAMQP(cfg config.Config, lc fx.Lifecycle) (queue.Queue, error) {
+	q, err := amqp.New(cfg.AMQP)
+	if err != nil {
+		return nil, fmt.Errorf("amqp: %w", err)
+	}
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			q.Stop()
+			return nil
+		},
+	})
+
+	return q, nil
+}
+
+func provideS3(cfg config.Config) (*aws.S3, error) {
+	return aws.NewS3(cfg.AWS)
+}
+
+func provideRedis(cfg config.Config, lc fx.Lifecycle) *redis.Client {
+	rds := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.Host,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return rds.Close()
+		},
+	})
+
+	return rds
+}
+
+func provideLogBroker(rds *redis.Client) *logstream.Broker {
+	return logstream.NewBroker(rds, 4096)
+}
+
+func provideSandbox(cfg config.Config) judge.Sandbox {
+	return newSandbox(cfg.Runner)
+}
+
+func provideJudge(cfg config.Config, sandbox judge.Sandbox) *judge.Judge {
+	return judge.New(sandbox, judge.LimitsFromConfig(cfg.Runner))
+}
+
+func provideWorkerPool() *workerpool.Pool {
+	return workerpool.New()
+}
+
+func provideSnapshotFetcher(cfg config.Config) *snapshotFetcher {
+	return newSnapshotFetcher(cfg.App)
+}
+
+func provideService(cfg config.Config, l logger.Logger, q queue.Queue, s3 *aws.S3, j *judge.Judge, logs *logstream.Broker, snapshot *snapshotFetcher) *service {
+	return &service{config: cfg, logger: l, queue: q, s3: s3, judge: j, logs: logs, snapshot: snapshot}
+}
+
+func registerConsumer(cfg config.Config, s *service, q queue.Queue, wp *workerpool.Pool, lc fx.Lifecycle) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			messages, err := q.Subscribe(cfg.App.TopicName)
+			if err != nil {
+				return fmt.Errorf("subscribe: %w", err)
+			}
+
+			wp.Start(cfg.Runner.Concurrency)
+			for i := 0; i < cfg.Runner.Concurrency; i++ {
+				wp.Submit(func() {
+					s.consume(messages)
+				})
+			}
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			wp.Stop()
+			return nil
+		},
+	})
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"grader/internal/app/runner/config"
+	"grader/internal/app/runner/judge"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dockerSandbox runs each submission inside a throwaway container via the
+// Docker CLI, bounding run time, memory, pids and network per runner.toml.
+// The cpu_time setting is enforced as a wall-clock timeout on the docker
+// command; CPU time consumed inside the container is not itself limited.
+// Every command is wrapped in /usr/bin/time -v so Run can report the peak
+// RSS the command used; its diagnostic block is written to a dedicated
+// bind-mounted file rather than stderr, so it never ends up in the
+// live-streamed program output.
+type dockerSandbox struct {
+	image string
+}
+
+func newSandbox(cfg config.Runner) *dockerSandbox {
+	return &dockerSandbox{image: "grader-runner-sandbox"}
+}
+
+func (s *dockerSandbox) Run(ctx context.Context, cmd []string, stdin []byte, workDir string, limits judge.Limits, stdout, stderr io.Writer) (judge.RunResult, error) {
+	timeout := limits.CPUTime
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	timeFile, err := os.CreateTemp(workDir, ".time-*.log")
+	if err != nil {
+		return judge.RunResult{}, fmt.Errorf("time output file: %w", err)
+	}
+	timeFile.Close()
+	defer os.Remove(timeFile.Name())
+	timeFileName := filepath.Base(timeFile.Name())
+
+	args := []string{
+		"run", "--rm", "-i",
+		"-v", fmt.Sprintf("%s:/workspace", workDir),
+		"-w", "/workspace",
+		"--memory", fmt.Sprintf("%dk", limits.Memory),
+		"--pids-limit", fmt.Sprintf("%d", limits.Pids),
+	}
+	if !limits.Network {
+		args = append(args, "--network", "none")
+	}
+	args = append(args, s.image)
+	args = append(args, "/usr/bin/time", "-v", "--output="+timeFileName)
+	args = append(args, cmd...)
+
+	c := exec.CommandContext(ctx, "docker", args...)
+	c.Stdin = bytes.NewReader(stdin)
+
+	var out, errOut bytes.Buffer
+	c.Stdout = io.MultiWriter(&out, stdout)
+	c.Stderr = io.MultiWriter(&errOut, stderr)
+
+	start := time.Now()
+	runErr := c.Run()
+	elapsed := time.Since(start)
+
+	timeOut, _ := os.ReadFile(timeFile.Name())
+
+	result := judge.RunResult{
+		Stdout:   out.Bytes(),
+		Stderr:   errOut.Bytes(),
+		TimeMs:   elapsed.Milliseconds(),
+		TimedOut: ctx.Err() == context.DeadlineExceeded,
+		MemoryKB: maxRSSKB(timeOut),
+	}
+	if exitErr, ok := runErr.(*exec.ExitError); ok {
+		result.ExitCode = exitErr.ExitCode()
+		return result, nil
+	}
+	if runErr != nil && !result.TimedOut {
+		return result, runErr
+	}
+
+	return result, nil
+}
+
+// maxRSSKB scans /usr/bin/time -v's --output file for the "Maximum resident
+// set size" line, returning 0 if it isn't present (e.g. the command was
+// killed before time could report).
+func maxRSSKB(timeOutput []byte) int64 {
+	const prefix = "Maximum resident set size (kbytes): "
+
+	scanner := bufio.NewScanner(bytes.NewReader(timeOutput))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+		kb, err := strconv.ParseInt(strings.TrimPrefix(line, prefix), 10, 64)
+		if err != nil {
+			return 0
+		}
+		return kb
+	}
+	return 0
+}
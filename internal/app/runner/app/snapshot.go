@@ -0,0 +1,107 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"grader/internal/app/runner/config"
+	"grader/internal/pkg/model"
+	"net/http"
+	"sync"
+)
+
+// snapshotFetcher fetches the immutable problem+testcases view the panel
+// froze for a (problemID, version) pair, authenticating as the runner's own
+// "system" group account so /app/api/problem/{id} treats it like any other
+// signed-in caller. Access tokens are short-lived, so it rotates the
+// runner's one-time-use refresh token for a fresh one before every fetch
+// rather than presenting a single long-lived credential.
+type snapshotFetcher struct {
+	cfg config.App
+
+	mu           sync.Mutex
+	refreshToken string
+}
+
+func newSnapshotFetcher(cfg config.App) *snapshotFetcher {
+	return &snapshotFetcher{cfg: cfg, refreshToken: cfg.PanelRefreshToken}
+}
+
+func (f *snapshotFetcher) fetch(ctx context.Context, problemID string, version int) (*model.ProblemSnapshot, error) {
+	access, err := f.refreshAccess(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("refresh panel session: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/app/api/problem/%s?version=%d", f.cfg.PanelBaseURL, problemID, version)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+	req.AddCookie(&http.Cookie{Name: "grader_session", Value: access})
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch snapshot: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch snapshot: unexpected status %d", resp.StatusCode)
+	}
+
+	var snapshot model.ProblemSnapshot
+	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("decode snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type refreshResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// refreshAccess rotates f.refreshToken for a fresh access token via the
+// panel's own POST /app/user/refresh, the same rotation every other signed-in
+// client goes through. The returned refresh token replaces f.refreshToken so
+// the next call rotates again instead of reusing a token the panel has
+// already consumed.
+func (f *snapshotFetcher) refreshAccess(ctx context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	body, err := json.Marshal(refreshRequest{RefreshToken: f.refreshToken})
+	if err != nil {
+		return "", fmt.Errorf("marshal refresh request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.cfg.PanelBaseURL+"/app/user/refresh", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("refresh: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("refresh: unexpected status %d", resp.StatusCode)
+	}
+
+	var out refreshResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("decode refresh response: %w", err)
+	}
+
+	f.refreshToken = out.RefreshToken
+	return out.AccessToken, nil
+}
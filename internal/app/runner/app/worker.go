@@ -0,0 +1,113 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"grader/internal/app/runner/config"
+	"grader/internal/app/runner/judge"
+	"grader/pkg/aws"
+	"grader/pkg/logger"
+	"grader/pkg/logstream"
+	"grader/pkg/queue"
+)
+
+// service consumes submission messages off the queue and judges them. It is
+// assembled by the fx providers in module.go.
+type service struct {
+	config   config.Config
+	logger   logger.Logger
+	queue    queue.Queue
+	s3       *aws.S3
+	judge    *judge.Judge
+	logs     *logstream.Broker
+	snapshot *snapshotFetcher
+}
+
+type submissionMessage struct {
+	ID        string `json:"id"`
+	SourceID  string `json:"source_id"`
+	ProblemID string `json:"problem_id"`
+	Version   int    `json:"version"`
+	Language  string `json:"language"`
+}
+
+func (s *service) consume(messages <-chan []byte) {
+	for raw := range messages {
+		var msg submissionMessage
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			s.logger.Error().Err(err).Msg("decode submission message")
+			continue
+		}
+
+		if err := s.handle(msg); err != nil {
+			s.logger.Error().Err(err).Str("submission_id", msg.ID).Msg("handle submission")
+		}
+	}
+}
+
+// maxStreamedOutput bounds how much build/run stdout+stderr a single
+// submission can push through the log stream, so a runaway submission can't
+// fill Redis with gigabytes of output.
+const maxStreamedOutput = 1 << 20
+
+func (s *service) handle(msg submissionMessage) error {
+	ctx := context.Background()
+
+	source, err := s.s3.GetObject(ctx, msg.SourceID)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := s.snapshot.fetch(ctx, msg.ProblemID, msg.Version)
+	if err != nil {
+		return fmt.Errorf("fetch snapshot: %w", err)
+	}
+
+	testcases := make([]judge.Testcase, len(snapshot.Testcases))
+	for i, tc := range snapshot.Testcases {
+		input, err := s.s3.GetObject(ctx, tc.InputKey)
+		if err != nil {
+			return fmt.Errorf("testcase %d input: %w", tc.Ordinal, err)
+		}
+		expected, err := s.s3.GetObject(ctx, tc.ExpectedKey)
+		if err != nil {
+			return fmt.Errorf("testcase %d expected: %w", tc.Ordinal, err)
+		}
+		testcases[i] = judge.Testcase{Ordinal: tc.Ordinal, Input: input, Expected: expected}
+	}
+
+	sub := judge.Submission{
+		ID:          msg.ID,
+		Language:    msg.Language,
+		Source:      source,
+		Testcases:   testcases,
+		TimeLimitMs: int64(snapshot.Problem.TimeLimitMs),
+		MemoryKB:    int64(snapshot.Problem.MemoryLimitKB),
+	}
+
+	stdout := logstream.NewLineWriter(ctx, s.logs, msg.ID, "stdout", maxStreamedOutput)
+	stderr := logstream.NewLineWriter(ctx, s.logs, msg.ID, "stderr", maxStreamedOutput)
+
+	result, err := s.judge.Run(ctx, sub, stdout, stderr)
+	if err != nil {
+		return err
+	}
+
+	for _, tc := range result.Testcases {
+		s.logs.Publish(ctx, msg.ID, logstream.Event{
+			Verdict:  string(tc.Verdict),
+			Testcase: tc.Ordinal,
+			TimeMs:   tc.TimeMs,
+			MemKB:    tc.MemoryKB,
+		})
+	}
+	s.logs.Publish(ctx, msg.ID, logstream.Event{Verdict: string(result.Verdict), Final: true})
+
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	return s.queue.Publish(s.config.App.ResultTopicName, payload)
+}
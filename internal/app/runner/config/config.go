@@ -0,0 +1,46 @@
+package config
+
+import (
+	"grader/pkg/logger"
+)
+
+type Config struct {
+	App    App
+	AMQP   AMQP
+	AWS    AWS
+	Redis  Redis
+	Runner Runner
+	Logger logger.Config `mapstructure:"log"`
+}
+
+type Redis struct {
+	Host     string `mapstructure:"host"`
+	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
+}
+
+type App struct {
+	TopicName         string `mapstructure:"topic_name"`
+	ResultTopicName   string `mapstructure:"result_topic_name"`
+	PanelBaseURL      string `mapstructure:"panel_base_url"`
+	PanelRefreshToken string `mapstructure:"panel_refresh_token"`
+}
+
+type AMQP struct {
+	DSN string `mapstructure:"dsn"`
+}
+
+type AWS struct {
+	Region          string `mapstructure:"region"`
+	Bucket          string `mapstructure:"bucket"`
+	AccessKeyID     string `mapstructure:"access_key_id"`
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+}
+
+type Runner struct {
+	Concurrency int    `mapstructure:"concurrency"`
+	CPUTime     string `mapstructure:"cpu_time"`
+	Memory      string `mapstructure:"memory"`
+	Pids        int    `mapstructure:"pids"`
+	Network     bool   `mapstructure:"network"`
+}
@@ -0,0 +1,130 @@
+package judge
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+type Testcase struct {
+	Ordinal  int
+	Input    []byte
+	Expected []byte
+}
+
+type Submission struct {
+	ID          string
+	Language    string
+	Source      []byte
+	Testcases   []Testcase
+	TimeLimitMs int64
+	MemoryKB    int64
+}
+
+type Judge struct {
+	sandbox Sandbox
+	limits  Limits
+}
+
+func New(sandbox Sandbox, limits Limits) *Judge {
+	return &Judge{sandbox: sandbox, limits: limits}
+}
+
+// Run judges sub, teeing the sandboxed build/run commands' stdout and stderr
+// to the given writers as they arrive so a caller can stream progress live
+// instead of waiting for Run to return. The source is materialized into a
+// workspace directory bind-mounted into every sandboxed container for this
+// submission, so the binary the build phase produces survives into each
+// testcase's separate run phase.
+func (j *Judge) Run(ctx context.Context, sub Submission, stdout, stderr io.Writer) (Result, error) {
+	workDir, err := os.MkdirTemp("", "judge-"+sub.ID+"-")
+	if err != nil {
+		return Result{}, fmt.Errorf("workdir: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	if err := os.WriteFile(filepath.Join(workDir, sourceFilename(sub.Language)), sub.Source, 0o644); err != nil {
+		return Result{}, fmt.Errorf("write source: %w", err)
+	}
+
+	if sub.Language == "go" {
+		if err := os.WriteFile(filepath.Join(workDir, "go.mod"), []byte("module solution\n\ngo 1.21\n"), 0o644); err != nil {
+			return Result{}, fmt.Errorf("write go.mod: %w", err)
+		}
+	}
+
+	build, err := j.sandbox.Run(ctx, buildCmd(sub.Language), nil, workDir, j.limits, stdout, stderr)
+	if err != nil {
+		return Result{}, fmt.Errorf("build: %w", err)
+	}
+	if build.ExitCode != 0 {
+		return Result{SubmissionID: sub.ID, Verdict: VerdictCE}, nil
+	}
+
+	res := Result{SubmissionID: sub.ID, Verdict: VerdictAC}
+	for _, tc := range sub.Testcases {
+		run, err := j.sandbox.Run(ctx, runCmd(sub.Language), tc.Input, workDir, j.limits, stdout, stderr)
+		if err != nil {
+			return Result{}, fmt.Errorf("testcase %d: %w", tc.Ordinal, err)
+		}
+
+		tr := TestcaseResult{Ordinal: tc.Ordinal, TimeMs: run.TimeMs, MemoryKB: run.MemoryKB}
+		switch {
+		case run.TimedOut:
+			tr.Verdict = VerdictTLE
+		case run.MemoryKB > sub.MemoryKB:
+			tr.Verdict = VerdictMLE
+		case run.ExitCode != 0:
+			tr.Verdict = VerdictRE
+		case !bytes.Equal(bytes.TrimSpace(run.Stdout), bytes.TrimSpace(tc.Expected)):
+			tr.Verdict = VerdictWA
+		default:
+			tr.Verdict = VerdictAC
+		}
+
+		res.Testcases = append(res.Testcases, tr)
+		if tr.Verdict != VerdictAC && res.Verdict == VerdictAC {
+			res.Verdict = tr.Verdict
+		}
+	}
+
+	return res, nil
+}
+
+// sourceFilename is the name the submitted source is written under inside
+// the workspace, matching what buildCmd invokes the compiler/interpreter on.
+func sourceFilename(language string) string {
+	switch language {
+	case "cpp":
+		return "main.cpp"
+	case "go":
+		return "main.go"
+	case "python":
+		return "main.py"
+	default:
+		return "main"
+	}
+}
+
+func buildCmd(language string) []string {
+	switch language {
+	case "cpp":
+		return []string{"g++", "-O2", "-o", "solution", "main.cpp"}
+	case "go":
+		return []string{"go", "build", "-o", "solution", "."}
+	default:
+		return []string{"true"}
+	}
+}
+
+func runCmd(language string) []string {
+	switch language {
+	case "python":
+		return []string{"python3", "main.py"}
+	default:
+		return []string{"./solution"}
+	}
+}
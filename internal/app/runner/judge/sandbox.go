@@ -0,0 +1,47 @@
+package judge
+
+import (
+	"context"
+	"grader/internal/app/runner/config"
+	"io"
+	"strconv"
+	"time"
+)
+
+type Limits struct {
+	CPUTime time.Duration
+	Memory  int64
+	Pids    int
+	Network bool
+}
+
+func LimitsFromConfig(cfg config.Runner) Limits {
+	cpuTime, _ := time.ParseDuration(cfg.CPUTime)
+	memory, _ := strconv.ParseInt(cfg.Memory, 10, 64)
+	return Limits{
+		CPUTime: cpuTime,
+		Memory:  memory,
+		Pids:    cfg.Pids,
+		Network: cfg.Network,
+	}
+}
+
+type RunResult struct {
+	Stdout   []byte
+	Stderr   []byte
+	ExitCode int
+	TimedOut bool
+	MemoryKB int64
+	TimeMs   int64
+}
+
+// Sandbox executes an untrusted command inside an isolated container with
+// the given resource limits, feeding it stdin and returning its output.
+// workDir is bind-mounted into the container as its working directory, so
+// files one Run call writes (e.g. a build's output binary) are visible to a
+// later Run call against the same workDir. Whatever the command writes to
+// stdout/stderr is also teed to the given writers as it arrives, so a caller
+// can stream progress live instead of waiting for Run to return.
+type Sandbox interface {
+	Run(ctx context.Context, cmd []string, stdin []byte, workDir string, limits Limits, stdout, stderr io.Writer) (RunResult, error)
+}
@@ -0,0 +1,25 @@
+package judge
+
+type Verdict string
+
+const (
+	VerdictAC  Verdict = "AC"
+	VerdictWA  Verdict = "WA"
+	VerdictTLE Verdict = "TLE"
+	VerdictMLE Verdict = "MLE"
+	VerdictRE  Verdict = "RE"
+	VerdictCE  Verdict = "CE"
+)
+
+type TestcaseResult struct {
+	Ordinal  int     `json:"ordinal"`
+	Verdict  Verdict `json:"verdict"`
+	TimeMs   int64   `json:"time_ms"`
+	MemoryKB int64   `json:"memory_kb"`
+}
+
+type Result struct {
+	SubmissionID string           `json:"submission_id"`
+	Verdict      Verdict          `json:"verdict"`
+	Testcases    []TestcaseResult `json:"testcases"`
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+// Problem is versioned: any edit to the statement, limits, languages or
+// testcases bumps Version, and submissions record the version they were
+// judged against so grading stays reproducible even as problems evolve.
+type Problem struct {
+	ID            uuid.UUID `json:"id"`
+	AssessmentID  uuid.UUID `json:"assessment_id"`
+	Statement     string    `json:"statement"`
+	TimeLimitMs   int       `json:"time_limit_ms"`
+	MemoryLimitKB int       `json:"memory_limit_kb"`
+	Languages     []string  `json:"languages"`
+	Version       int       `json:"version"`
+}
+
+type Testcase struct {
+	ID          uuid.UUID `json:"id"`
+	ProblemID   uuid.UUID `json:"problem_id"`
+	Ordinal     int       `json:"ordinal"`
+	InputKey    string    `json:"-"`
+	ExpectedKey string    `json:"-"`
+	Hidden      bool      `json:"hidden"`
+	Points      int       `json:"points"`
+}
+
+// RunnerTestcase is the runner-facing view of a Testcase: unlike Testcase
+// itself (whose InputKey/ExpectedKey are hidden from admin-facing JSON), it
+// exposes the S3 keys the runner must fetch to judge a submission.
+type RunnerTestcase struct {
+	ID          uuid.UUID `json:"id"`
+	Ordinal     int       `json:"ordinal"`
+	InputKey    string    `json:"input_key"`
+	ExpectedKey string    `json:"expected_key"`
+	Hidden      bool      `json:"hidden"`
+	Points      int       `json:"points"`
+}
+
+// ProblemSnapshot is the immutable view of a Problem at a given Version, the
+// form the runner fetches before judging a submission.
+type ProblemSnapshot struct {
+	Problem   Problem          `json:"problem"`
+	Testcases []RunnerTestcase `json:"testcases"`
+}
@@ -0,0 +1,18 @@
+package model
+
+// SubmissionResult is the judged outcome of a submission: its overall
+// Verdict plus one TestcaseResult per testcase. It mirrors the payload the
+// runner publishes to the result queue topic, so the panel can persist the
+// same value it decodes off that topic.
+type SubmissionResult struct {
+	SubmissionID string           `json:"submission_id"`
+	Verdict      string           `json:"verdict"`
+	Testcases    []TestcaseResult `json:"testcases"`
+}
+
+type TestcaseResult struct {
+	Ordinal  int    `json:"ordinal"`
+	Verdict  string `json:"verdict"`
+	TimeMs   int64  `json:"time_ms"`
+	MemoryKB int64  `json:"memory_kb"`
+}
@@ -8,9 +8,21 @@ type User struct {
 	ID       uuid.UUID `json:"id"`
 	Name     string    `json:"name"`
 	Password string    `json:"-"`
-	IsAdmin  bool      `json:"-"`
+	Groups   []string  `json:"-"`
 }
 
 func (u *User) Identity() string {
 	return u.ID.String()
 }
+
+// HasGroup reports whether the user belongs to any of the given groups.
+func (u *User) HasGroup(groups ...string) bool {
+	for _, has := range u.Groups {
+		for _, want := range groups {
+			if has == want {
+				return true
+			}
+		}
+	}
+	return false
+}
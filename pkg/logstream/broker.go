@@ -0,0 +1,117 @@
+package logstream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"time"
+)
+
+const ringTTL = 1 * time.Hour
+
+// Broker fans log/verdict events for a submission out to Redis, both for
+// live pub/sub delivery and for a bounded ring buffer that lets a
+// just-connected subscriber catch up on lines it missed.
+type Broker struct {
+	rds      *redis.Client
+	ringSize int64
+}
+
+func NewBroker(rds *redis.Client, ringSize int) *Broker {
+	return &Broker{rds: rds, ringSize: int64(ringSize)}
+}
+
+func (b *Broker) Publish(ctx context.Context, submissionID string, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+
+	pipe := b.rds.TxPipeline()
+	pipe.RPush(ctx, ringKey(submissionID), payload)
+	pipe.LTrim(ctx, ringKey(submissionID), -b.ringSize, -1)
+	pipe.Expire(ctx, ringKey(submissionID), ringTTL)
+	pipe.Publish(ctx, channelKey(submissionID), payload)
+
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// Subscribe replays the buffered ring for submissionID and then tails live
+// events, closing the returned channel once a Final event is observed or ctx
+// is cancelled. The returned cancel func must be called to release the
+// underlying subscription.
+func (b *Broker) Subscribe(ctx context.Context, submissionID string) (<-chan Event, func(), error) {
+	sub := b.rds.Subscribe(ctx, channelKey(submissionID))
+	if _, err := sub.Receive(ctx); err != nil {
+		return nil, nil, fmt.Errorf("subscribe: %w", err)
+	}
+
+	ring, err := b.replay(ctx, submissionID)
+	if err != nil {
+		sub.Close()
+		return nil, nil, err
+	}
+
+	events := make(chan Event, 64)
+	go func() {
+		defer close(events)
+
+		for _, e := range ring.Snapshot() {
+			select {
+			case events <- e:
+			case <-ctx.Done():
+				return
+			}
+			if e.Final {
+				return
+			}
+		}
+
+		for msg := range sub.Channel() {
+			var e Event
+			if json.Unmarshal([]byte(msg.Payload), &e) != nil {
+				continue
+			}
+
+			select {
+			case events <- e:
+			case <-ctx.Done():
+				return
+			}
+			if e.Final {
+				return
+			}
+		}
+	}()
+
+	return events, func() { sub.Close() }, nil
+}
+
+// replay decodes the buffered ring for submissionID into a RingBuffer, the
+// same bounded structure a Broker hands a just-connected subscriber before
+// it starts tailing live events.
+func (b *Broker) replay(ctx context.Context, submissionID string) (*RingBuffer, error) {
+	raw, err := b.rds.LRange(ctx, ringKey(submissionID), 0, -1).Result()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("replay: %w", err)
+	}
+
+	ring := NewRingBuffer(int(b.ringSize))
+	for _, item := range raw {
+		var e Event
+		if json.Unmarshal([]byte(item), &e) == nil {
+			ring.Add(e)
+		}
+	}
+	return ring, nil
+}
+
+func ringKey(submissionID string) string {
+	return "logstream:ring:" + submissionID
+}
+
+func channelKey(submissionID string) string {
+	return "logstream:channel:" + submissionID
+}
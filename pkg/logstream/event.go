@@ -0,0 +1,19 @@
+package logstream
+
+// Event is the wire format shared by the runner (producer) and the panel
+// (consumer) over Redis pub/sub. A log line carries Stream/Text, a
+// per-testcase result carries Verdict/Testcase/TimeMs/MemKB, and Final marks
+// the one event that ends the submission — a judged submission publishes one
+// Verdict event per testcase before it, so only Final may close the stream.
+// Seq lets the client detect gaps across the replay/live boundary.
+type Event struct {
+	Seq      int    `json:"seq"`
+	Ts       int64  `json:"ts,omitempty"`
+	Stream   string `json:"stream,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Verdict  string `json:"verdict,omitempty"`
+	Testcase int    `json:"testcase,omitempty"`
+	TimeMs   int64  `json:"time_ms,omitempty"`
+	MemKB    int64  `json:"mem_kb,omitempty"`
+	Final    bool   `json:"final,omitempty"`
+}
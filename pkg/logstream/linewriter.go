@@ -0,0 +1,58 @@
+package logstream
+
+import (
+	"bytes"
+	"context"
+)
+
+// LineWriter splits whatever is written to it into lines and hands each
+// complete line to a Broker as a log Event, bounding the total bytes it
+// forwards so a runaway submission can't fill Redis with gigabytes of output.
+// Bytes past the bound are dropped but still reported as written.
+type LineWriter struct {
+	ctx     context.Context
+	broker  *Broker
+	subID   string
+	stream  string
+	max     int64
+	written int64
+	seq     int
+	buf     []byte
+}
+
+func NewLineWriter(ctx context.Context, broker *Broker, submissionID, stream string, max int64) *LineWriter {
+	return &LineWriter{ctx: ctx, broker: broker, subID: submissionID, stream: stream, max: max}
+}
+
+func (w *LineWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	if w.written >= w.max {
+		return n, nil
+	}
+	if remaining := w.max - w.written; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	w.written += int64(len(p))
+	w.buf = append(w.buf, p...)
+
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		line := string(w.buf[:i])
+		w.buf = w.buf[i+1:]
+
+		w.seq++
+		if err := w.broker.Publish(w.ctx, w.subID, Event{
+			Seq:    w.seq,
+			Stream: w.stream,
+			Text:   line,
+		}); err != nil {
+			return n, err
+		}
+	}
+
+	return n, nil
+}
@@ -0,0 +1,34 @@
+package logstream
+
+import "sync"
+
+// RingBuffer keeps the last N events in memory so a Broker can hand a
+// just-connected subscriber a snapshot before it starts tailing live events.
+type RingBuffer struct {
+	mu     sync.Mutex
+	events []Event
+	size   int
+}
+
+func NewRingBuffer(size int) *RingBuffer {
+	return &RingBuffer{size: size}
+}
+
+func (r *RingBuffer) Add(e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, e)
+	if len(r.events) > r.size {
+		r.events = r.events[len(r.events)-r.size:]
+	}
+}
+
+func (r *RingBuffer) Snapshot() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]Event, len(r.events))
+	copy(out, r.events)
+	return out
+}
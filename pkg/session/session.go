@@ -0,0 +1,194 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"grader/pkg/token"
+	"net/http"
+	"time"
+)
+
+const cookieName = "grader_session"
+
+type ctxKey string
+
+const claimsCtxKey ctxKey = "session.claims"
+
+type Option func(*Manager)
+
+func WithSessionLifetime(d time.Duration) Option {
+	return func(m *Manager) { m.accessLifetime = d }
+}
+
+func WithRefreshLifetime(d time.Duration) Option {
+	return func(m *Manager) { m.refreshLifetime = d }
+}
+
+// Manager issues and validates the access/refresh cookie pair for a signed-in
+// user, keeping a revocable record of every live session in Redis so a
+// logout (or logout-all) takes effect immediately instead of waiting out the
+// access token's own expiry.
+type Manager struct {
+	rds             *redis.Client
+	tokens          token.Manager
+	accessLifetime  time.Duration
+	refreshLifetime time.Duration
+}
+
+func NewRedis(rds *redis.Client, tokens token.Manager, opts ...Option) *Manager {
+	m := &Manager{
+		rds:             rds,
+		tokens:          tokens,
+		accessLifetime:  15 * time.Minute,
+		refreshLifetime: 30 * 24 * time.Hour,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+type sessionMeta struct {
+	UserAgent string    `json:"user_agent"`
+	IssuedAt  time.Time `json:"issued_at"`
+}
+
+func refreshKey(userID, sid string) string {
+	return fmt.Sprintf("refresh:%s:%s", userID, sid)
+}
+
+func sessionKey(userID, sid string) string {
+	return fmt.Sprintf("session:%s:%s", userID, sid)
+}
+
+// Issue mints a fresh access/refresh pair for userID and records the session
+// in Redis, keyed by its jti (sid), so it can later be revoked or rotated.
+func (m *Manager) Issue(ctx context.Context, userID, userAgent string) (access, refresh string, err error) {
+	access, refresh, err = m.tokens.NewPair(userID)
+	if err != nil {
+		return "", "", fmt.Errorf("new pair: %w", err)
+	}
+
+	claims, err := m.tokens.ParseRefresh(refresh)
+	if err != nil {
+		return "", "", fmt.Errorf("parse refresh: %w", err)
+	}
+
+	meta, err := json.Marshal(sessionMeta{UserAgent: userAgent, IssuedAt: time.Now()})
+	if err != nil {
+		return "", "", fmt.Errorf("marshal session meta: %w", err)
+	}
+
+	pipe := m.rds.TxPipeline()
+	pipe.Set(ctx, refreshKey(userID, claims.SID), meta, m.refreshLifetime)
+	pipe.Set(ctx, sessionKey(userID, claims.SID), meta, m.refreshLifetime)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", "", fmt.Errorf("store session: %w", err)
+	}
+
+	return access, refresh, nil
+}
+
+// Refresh validates refreshToken against Redis, rotates it (deleting the old
+// jti so reuse of a stolen refresh token is detected and rejected), and
+// returns a freshly issued access/refresh pair.
+func (m *Manager) Refresh(ctx context.Context, userAgent, refreshToken string) (access, refresh string, err error) {
+	claims, err := m.tokens.ParseRefresh(refreshToken)
+	if err != nil {
+		return "", "", fmt.Errorf("parse refresh: %w", err)
+	}
+
+	n, err := m.rds.Exists(ctx, refreshKey(claims.UserID, claims.SID)).Result()
+	if err != nil {
+		return "", "", fmt.Errorf("lookup refresh: %w", err)
+	}
+	if n == 0 {
+		return "", "", fmt.Errorf("refresh token revoked or already used")
+	}
+
+	pipe := m.rds.TxPipeline()
+	pipe.Del(ctx, refreshKey(claims.UserID, claims.SID))
+	pipe.Del(ctx, sessionKey(claims.UserID, claims.SID))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", "", fmt.Errorf("rotate refresh: %w", err)
+	}
+
+	return m.Issue(ctx, claims.UserID, userAgent)
+}
+
+// LogoutAll revokes every session issued to userID, so every device using
+// this account is forced to sign in again.
+func (m *Manager) LogoutAll(ctx context.Context, userID string) error {
+	var keys []string
+	for _, pattern := range []string{fmt.Sprintf("refresh:%s:*", userID), fmt.Sprintf("session:%s:*", userID)} {
+		found, err := m.scanKeys(ctx, pattern)
+		if err != nil {
+			return fmt.Errorf("list sessions: %w", err)
+		}
+		keys = append(keys, found...)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return m.rds.Del(ctx, keys...).Err()
+}
+
+// scanKeys walks the keyspace with SCAN rather than KEYS so revoking every
+// session for a user doesn't block Redis with an O(N) scan of all keys.
+func (m *Manager) scanKeys(ctx context.Context, pattern string) ([]string, error) {
+	var keys []string
+	var cursor uint64
+	for {
+		batch, next, err := m.rds.Scan(ctx, cursor, pattern, 100).Result()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, batch...)
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+	return keys, nil
+}
+
+// IsRevoked reports whether the session identified by sid has been rotated
+// or logged out, i.e. no longer has a live session marker in Redis.
+func (m *Manager) IsRevoked(ctx context.Context, userID, sid string) (bool, error) {
+	n, err := m.rds.Exists(ctx, sessionKey(userID, sid)).Result()
+	if err != nil {
+		return false, fmt.Errorf("lookup session: %w", err)
+	}
+	return n == 0, nil
+}
+
+// ContextMiddleware parses the access token from the session cookie and, if
+// present, stores its Claims on the request context for
+// auth.ContextMiddleware to resolve into a *model.User.
+func ContextMiddleware(m *Manager) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cookie, err := r.Cookie(cookieName)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			claims, err := m.tokens.Parse(cookie.Value)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), claimsCtxKey, claims)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func ClaimsFromContext(ctx context.Context) (token.Claims, bool) {
+	c, ok := ctx.Value(claimsCtxKey).(token.Claims)
+	return c, ok
+}
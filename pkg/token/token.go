@@ -0,0 +1,144 @@
+package token
+
+import (
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Claims is the subset of a parsed token the rest of the app cares about.
+// SID identifies the sign-in session an access/refresh pair belongs to, so a
+// revoked session can be rejected even while the access token itself hasn't
+// expired yet.
+type Claims struct {
+	UserID string
+	SID    string
+}
+
+// Manager mints and validates the JWTs issued for a signed-in user: a single
+// short-lived access token, or an access/refresh pair sharing a session id.
+type Manager interface {
+	New(userID string) (string, error)
+	Parse(raw string) (Claims, error)
+	NewPair(userID string) (access, refresh string, err error)
+	ParseRefresh(raw string) (Claims, error)
+}
+
+type Option func(*jwtManager)
+
+func WithAccessLifetime(d time.Duration) Option {
+	return func(m *jwtManager) { m.accessLifetime = d }
+}
+
+func WithRefreshLifetime(d time.Duration) Option {
+	return func(m *jwtManager) { m.refreshLifetime = d }
+}
+
+type jwtManager struct {
+	secret          []byte
+	accessLifetime  time.Duration
+	refreshLifetime time.Duration
+}
+
+func NewJWT(secretKey string, opts ...Option) (Manager, error) {
+	if secretKey == "" {
+		return nil, fmt.Errorf("token: secret key is empty")
+	}
+
+	m := &jwtManager{
+		secret:          []byte(secretKey),
+		accessLifetime:  15 * time.Minute,
+		refreshLifetime: 30 * 24 * time.Hour,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m, nil
+}
+
+// tokenType distinguishes an access token from a refresh token so one can
+// never be accepted in place of the other, even though both carry the same
+// sid for a given sign-in session.
+type tokenType string
+
+const (
+	typeAccess  tokenType = "access"
+	typeRefresh tokenType = "refresh"
+)
+
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	SID string    `json:"sid,omitempty"`
+	Typ tokenType `json:"typ"`
+}
+
+func (m *jwtManager) New(userID string) (string, error) {
+	return m.sign(userID, "", typeAccess, m.accessLifetime)
+}
+
+func (m *jwtManager) NewPair(userID string) (access, refresh string, err error) {
+	sid := uuid.NewString()
+
+	access, err = m.sign(userID, sid, typeAccess, m.accessLifetime)
+	if err != nil {
+		return "", "", fmt.Errorf("sign access token: %w", err)
+	}
+
+	refresh, err = m.sign(userID, sid, typeRefresh, m.refreshLifetime)
+	if err != nil {
+		return "", "", fmt.Errorf("sign refresh token: %w", err)
+	}
+
+	return access, refresh, nil
+}
+
+func (m *jwtManager) sign(userID, sid string, typ tokenType, lifetime time.Duration) (string, error) {
+	now := time.Now()
+	claims := jwtClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(lifetime)),
+		},
+		SID: sid,
+		Typ: typ,
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+}
+
+func (m *jwtManager) Parse(raw string) (Claims, error) {
+	claims, typ, err := m.parse(raw)
+	if err != nil {
+		return Claims{}, err
+	}
+	if typ != typeAccess {
+		return Claims{}, fmt.Errorf("parse token: expected an access token, got %q", typ)
+	}
+	return claims, nil
+}
+
+func (m *jwtManager) ParseRefresh(raw string) (Claims, error) {
+	claims, typ, err := m.parse(raw)
+	if err != nil {
+		return Claims{}, err
+	}
+	if typ != typeRefresh {
+		return Claims{}, fmt.Errorf("parse refresh token: expected a refresh token, got %q", typ)
+	}
+	return claims, nil
+}
+
+func (m *jwtManager) parse(raw string) (Claims, tokenType, error) {
+	var claims jwtClaims
+	_, err := jwt.ParseWithClaims(raw, &claims, func(t *jwt.Token) (interface{}, error) {
+		return m.secret, nil
+	})
+	if err != nil {
+		return Claims{}, "", fmt.Errorf("parse token: %w", err)
+	}
+
+	return Claims{UserID: claims.Subject, SID: claims.SID}, claims.Typ, nil
+}